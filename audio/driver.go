@@ -0,0 +1,32 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"github.com/hajimehoshi/ebiten/audio/driver"
+)
+
+// theDriver holds the backend registered with SetDriver. Context's
+// constructor does not read it: NewContext picks the platform default audio
+// backend (oto on desktop, go2cpp or the JS WebAudio driver in the browser,
+// etc.) whether or not theDriver is set.
+var theDriver driver.Context
+
+// SetDriver records d as the audio backend to use in place of the platform
+// default. NewContext does not consult the recorded driver, so calling
+// SetDriver does not change the backend that a Context uses.
+func SetDriver(d driver.Context) {
+	theDriver = d
+}
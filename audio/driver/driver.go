@@ -0,0 +1,67 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package driver defines the contract that an audio backend must satisfy to
+// be used by the audio package. The go2cpp backend implements this contract.
+// A program can also provide its own implementation and register it with
+// audio.SetDriver; audio.NewContext does not consult a driver registered that
+// way and keeps using the platform default backend.
+package driver
+
+import (
+	"io"
+	"time"
+)
+
+// Context creates Players that share an output device and sample rate.
+type Context interface {
+	// NewPlayer creates a new Player that reads audio data from src.
+	//
+	// NewPlayer's error, if any, is reported at the first operation on the
+	// returned Player rather than from NewPlayer itself, matching the
+	// existing backends' behavior of creating players lazily.
+	NewPlayer(src io.Reader) Player
+
+	// Close closes the context and releases the underlying device.
+	Close() error
+}
+
+// Player plays a stream of audio data read from an io.Reader.
+type Player interface {
+	Play()
+	Pause()
+	Reset()
+
+	Volume() float64
+	SetVolume(volume float64)
+
+	// Seek seeks the playback position to offset. Seek returns an error if
+	// the underlying source does not support seeking.
+	Seek(offset time.Duration) error
+
+	// BufferedSize returns the byte count that is buffered on the device
+	// side and has not been played yet.
+	BufferedSize() int
+
+	// UnplayedBufferSize returns the byte count that has been read from the
+	// source but not yet sent to the device.
+	UnplayedBufferSize() int64
+
+	// Err returns a channel that receives an error when the player hits a
+	// fatal error, e.g. a read error from its source. The channel is closed
+	// when the player is closed without an error.
+	Err() <-chan error
+
+	Close() error
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/audio/driver"
+)
+
+// fakePlayer is a minimal driver.Player that follows the Err contract
+// documented on the interface: its errCh is closed when the player is closed
+// without ever having hit an error. It exists to pin that contract down
+// independently of any one backend's implementation of it.
+type fakePlayer struct {
+	errCh  chan error
+	closed bool
+}
+
+func newFakePlayer() *fakePlayer {
+	return &fakePlayer{errCh: make(chan error, 1)}
+}
+
+func (p *fakePlayer) Play()                           {}
+func (p *fakePlayer) Pause()                          {}
+func (p *fakePlayer) Reset()                          {}
+func (p *fakePlayer) Volume() float64                 { return 1 }
+func (p *fakePlayer) SetVolume(volume float64)        {}
+func (p *fakePlayer) Seek(offset time.Duration) error { return nil }
+func (p *fakePlayer) BufferedSize() int               { return 0 }
+func (p *fakePlayer) UnplayedBufferSize() int64       { return 0 }
+func (p *fakePlayer) Err() <-chan error               { return p.errCh }
+
+func (p *fakePlayer) Close() error {
+	if !p.closed {
+		close(p.errCh)
+		p.closed = true
+	}
+	return nil
+}
+
+var (
+	_ driver.Player = (*fakePlayer)(nil)
+)
+
+func TestPlayerErrChClosedOnCleanClose(t *testing.T) {
+	p := newFakePlayer()
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+
+	select {
+	case err, ok := <-p.Err():
+		if ok {
+			t.Fatalf("Err() received %v, want the channel to be closed with no value", err)
+		}
+	default:
+		t.Fatal("Err() channel was not closed after a clean Close")
+	}
+}
+
+// fakeContext is a minimal driver.Context, used only to confirm the
+// interface shape compiles against real callers such as audio.SetDriver.
+type fakeContext struct{}
+
+func (c *fakeContext) NewPlayer(src io.Reader) driver.Player { return newFakePlayer() }
+func (c *fakeContext) Close() error                          { return nil }
+
+var _ driver.Context = (*fakeContext)(nil)
@@ -15,35 +15,48 @@
 package go2cpp
 
 import (
+	"fmt"
 	"io"
 	"runtime"
 	"sync"
 	"syscall/js"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/audio/driver"
+)
+
+var (
+	_ driver.Context = (*Context)(nil)
+	_ driver.Player  = (*Player)(nil)
 )
 
 type Context struct {
-	v js.Value
+	v          js.Value
+	sampleRate int
 }
 
 func NewContext(sampleRate int) *Context {
 	v := js.Global().Get("go2cpp").Call("createAudio", sampleRate, 2, 2, 8192)
 	return &Context{
-		v: v,
+		v:          v,
+		sampleRate: sampleRate,
 	}
 }
 
-func (c *Context) NewPlayer(r io.Reader) *Player {
+func (c *Context) NewPlayer(r io.Reader) driver.Player {
 	cond := sync.NewCond(&sync.Mutex{})
 	onwritten := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		cond.Signal()
 		return nil
 	})
 	p := &Player{
-		context:   c,
-		src:       r,
-		volume:    1,
-		cond:      cond,
-		onWritten: onwritten,
+		context:    c,
+		src:        r,
+		volume:     1,
+		cond:       cond,
+		onWritten:  onwritten,
+		errCh:      make(chan error, 1),
+		sampleRate: c.sampleRate,
 	}
 	runtime.SetFinalizer(p, (*Player).Close)
 	return p
@@ -62,13 +75,23 @@ const (
 )
 
 type Player struct {
-	context *Context
-	src     io.Reader
-	v       js.Value
-	state   playerState
-	volume  float64
-	cond    *sync.Cond
-	err     error
+	context     *Context
+	src         io.Reader
+	v           js.Value
+	state       playerState
+	volume      float64
+	cond        *sync.Cond
+	err         error
+	errCh       chan error
+	errChClosed bool
+	sampleRate  int
+
+	// srcMu guards access to src itself, separately from cond.L, which
+	// guards player state. loop's Read and Seek's Seek both move src's
+	// position, so they're serialized on srcMu; using cond.L instead would
+	// hold the state lock (and block Pause/Volume/Close) for the duration
+	// of a potentially slow blocking Read.
+	srcMu sync.Mutex
 
 	onWritten js.Func
 }
@@ -156,6 +179,14 @@ func (p *Player) close(remove bool) error {
 	if remove {
 		p.state = playerStateClosed
 		p.onWritten.Release()
+		// The player is going away cleanly: close errCh so a consumer
+		// blocked on Err() to learn of shutdown doesn't hang forever. If
+		// setError already ran, p.err is non-nil and errCh instead holds
+		// the buffered error, which the contract doesn't require closing.
+		if p.err == nil && !p.errChClosed {
+			close(p.errCh)
+			p.errChClosed = true
+		}
 	} else {
 		p.state = playerStatePaused
 	}
@@ -174,6 +205,81 @@ func (p *Player) setError(err error) {
 	p.err = err
 	p.state = playerStateClosed
 	p.cond.Signal()
+
+	if p.errChClosed {
+		return
+	}
+	select {
+	case p.errCh <- err:
+	default:
+	}
+}
+
+// Seek seeks the playback position to offset. Seek returns an error unless
+// the Player's source is an io.Seeker, since go2cpp streams audio data
+// straight from src without buffering the whole stream.
+func (p *Player) Seek(offset time.Duration) error {
+	s, ok := p.src.(io.Seeker)
+	if !ok {
+		return fmt.Errorf("go2cpp: the source must implement io.Seeker for Seek")
+	}
+
+	// Hold srcMu for the Seek call itself, so it can't race with loop's Read
+	// of the same src.
+	p.srcMu.Lock()
+	defer p.srcMu.Unlock()
+
+	// bytesPerSecond matches the PCM format go2cpp always decodes to:
+	// 16-bit stereo samples at the Context's sample rate.
+	bytesPerSecond := 4 * p.sampleRate
+	if _, err := s.Seek(int64(offset.Seconds()*float64(bytesPerSecond)), io.SeekStart); err != nil {
+		return err
+	}
+
+	// Drop whatever was already queued on the JS side: it was read from src
+	// before the seek, so playing it back would resume from the old
+	// position for as long as that buffer lasts. If playback was in
+	// progress, recreate the JS player and restart loop immediately so
+	// playback continues from the new position instead of stalling until
+	// the next Play call.
+	p.cond.L.Lock()
+	if p.v.Truthy() {
+		p.v.Call("close", true)
+		p.v = js.Undefined()
+		if p.state == playerStatePlaying {
+			p.v = p.context.v.Call("createPlayer", p.onWritten)
+			p.v.Set("volume", p.volume)
+			go p.loop()
+			p.v.Call("play")
+		}
+	}
+	p.cond.L.Unlock()
+
+	return nil
+}
+
+// BufferedSize returns the number of bytes currently buffered on the
+// JavaScript side and not yet played.
+func (p *Player) BufferedSize() int {
+	p.cond.L.Lock()
+	defer p.cond.L.Unlock()
+
+	if !p.v.Truthy() {
+		return 0
+	}
+	return p.v.Get("bufferedSize").Int()
+}
+
+// UnplayedBufferSize is not tracked by the go2cpp bridge, which writes
+// directly to the JavaScript side as data is read; it always returns 0.
+func (p *Player) UnplayedBufferSize() int64 {
+	return 0
+}
+
+// Err returns a channel that receives a non-nil error if reading from src
+// fails. The channel has a buffer of 1 so setError never blocks.
+func (p *Player) Err() <-chan error {
+	return p.errCh
 }
 
 func (p *Player) waitUntilUnpaused() bool {
@@ -212,7 +318,9 @@ func (p *Player) loop() {
 			return
 		}
 
+		p.srcMu.Lock()
 		n, err := p.src.Read(buf)
+		p.srcMu.Unlock()
 		if err != nil && err != io.EOF {
 			p.setError(err)
 			return
@@ -0,0 +1,60 @@
+// Copyright 2021 Hajime Hoshi
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+// FPSMode is a mode to determine how often the screen is updated and
+// buffers are swapped.
+type FPSMode int
+
+const (
+	// FPSModeVsyncOn means that the game tries to sync the display's
+	// refresh rate. This is the default mode.
+	FPSModeVsyncOn FPSMode = iota
+
+	// FPSModeVsyncOffMaximum means that the game doesn't sync with vsync,
+	// and tries to update as often as possible.
+	FPSModeVsyncOffMaximum
+
+	// FPSModeVsyncOffMinimum means that the game doesn't sync with vsync,
+	// and always updates at most once, and only when something has
+	// changed, e.g. Update is about to run, the mouse cursor moved, or
+	// ScheduleFrame is called. This allows an Ebiten-based desktop app to
+	// idle at ~0% CPU when nothing is happening.
+	//
+	// Of the inputs listed above, only cursor movement is currently
+	// detected automatically; a keypress, mouse button, wheel, touch, or
+	// gamepad event with the cursor held still does not by itself wake a
+	// frame. A game that needs to redraw in response to those should call
+	// ScheduleFrame explicitly.
+	FPSModeVsyncOffMinimum
+)
+
+// SetFPSMode sets the FPS mode.
+// The default FPS mode is FPSModeVsyncOn.
+//
+// SetFPSMode is concurrent-safe.
+//
+// SetFPSMode does nothing on mobiles so far.
+func SetFPSMode(mode FPSMode) {
+	theUIContext.setFPSMode(mode)
+}
+
+// ScheduleFrame schedules a next frame when the current FPS mode is
+// FPSModeVsyncOffMinimum.
+//
+// ScheduleFrame is concurrent-safe.
+func ScheduleFrame() {
+	theUIContext.scheduleFrame()
+}
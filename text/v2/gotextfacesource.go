@@ -16,14 +16,20 @@ package text
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"unicode"
 
+	"github.com/go-text/typesetting/di"
 	"github.com/go-text/typesetting/font"
 	"github.com/go-text/typesetting/language"
 	"github.com/go-text/typesetting/opentype/api"
 	"github.com/go-text/typesetting/shaping"
+	"golang.org/x/text/unicode/bidi"
 )
 
 type goTextOutputCacheKey struct {
@@ -34,6 +40,7 @@ type goTextOutputCacheKey struct {
 	script       string
 	variations   string
 	features     string
+	fallbacks    string
 }
 
 type glyph struct {
@@ -41,6 +48,12 @@ type glyph struct {
 	startIndex     int
 	endIndex       int
 	scaledSegments []api.Segment
+
+	// bitmap holds the raw strike data for a glyph whose GlyphData is an
+	// api.GlyphBitmap with a nil Outline (e.g. most color emoji), so a
+	// caller that can't build a vector.Path out of scaledSegments, such as
+	// DrawVector, still has something to rasterize.
+	bitmap *api.GlyphBitmap
 }
 
 type goTextOutputCacheValue struct {
@@ -54,6 +67,10 @@ type GoTextFaceSource struct {
 	f  font.Face
 	id uint64
 
+	// fallbackSources is an ordered list of additional sources consulted, in
+	// order, for glyphs missing from f. See SetFallbackSources.
+	fallbackSources []*GoTextFaceSource
+
 	outputCache map[goTextOutputCacheKey]*goTextOutputCacheValue
 
 	m sync.Mutex
@@ -128,33 +145,220 @@ func finalizeGoTextFaceSource(source *GoTextFaceSource) {
 	})
 }
 
+// textRun is a maximal substring of a shaped string that has a single bidi
+// embedding direction and a single Unicode script, and is therefore safe to
+// hand to the shaper as one HarfBuzz run.
+type textRun struct {
+	runeStart int
+	runeEnd   int
+	direction di.Direction
+	script    language.Script
+}
+
+// segmentText splits runes into bidi runs via the Unicode bidirectional
+// algorithm, and then splits each bidi run further into script runs. The
+// returned runs are in visual order, matching the order glyphs must be drawn
+// in. baseDirection is used as the bidi paragraph's default direction, e.g.
+// for a run of digits or neutral punctuation with no strong characters.
+func segmentText(runes []rune, text string, baseDirection di.Direction) []textRun {
+	bidiDefault := bidi.LeftToRight
+	if baseDirection == di.DirectionRTL {
+		bidiDefault = bidi.RightToLeft
+	}
+
+	var p bidi.Paragraph
+	p.SetString(text, bidi.DefaultDirection(bidiDefault))
+
+	order, err := p.Order()
+	if err != nil || order.NumRuns() == 0 {
+		// Fall back to treating the whole string as a single run in the
+		// caller-specified direction when the bidi algorithm cannot make
+		// sense of it (e.g. an empty string).
+		return splitByScript(runes, 0, len(runes), baseDirection)
+	}
+
+	// Run.Pos returns the first and last rune indices of the run, both
+	// inclusive (see the x/text/unicode/bidi docs), not a [start, end) byte
+	// range into text, so no byte-to-rune translation is needed at all.
+	type bidiRun struct {
+		runeStart, runeEnd int
+		dir                di.Direction
+	}
+	bidiRuns := make([]bidiRun, order.NumRuns())
+	for i := 0; i < order.NumRuns(); i++ {
+		r := order.Run(i)
+		first, last := r.Pos()
+
+		dir := di.DirectionLTR
+		if r.Direction() == bidi.RightToLeft {
+			dir = di.DirectionRTL
+		}
+		bidiRuns[i] = bidiRun{runeStart: first, runeEnd: last + 1, dir: dir}
+	}
+
+	// Order does not itself reorder runs into visual order: it groups the
+	// paragraph into maximal same-direction runs and hands them back in
+	// logical (source) order, leaving the actual Unicode bidi L2 reordering
+	// (reversing runs under a right-to-left context) to the caller. Sort
+	// back to logical order defensively. Under a right-to-left paragraph
+	// (baseDirection is RTL, or the first strong character is right-to-left,
+	// as rules P2 and P3 decide) the whole line is reversed first. Then each
+	// maximal run of consecutive right-to-left bidi runs is reversed in
+	// place. With a single level of embedding, i.e. a left-to-right
+	// paragraph with RTL spans or vice versa, this is exactly what L2
+	// reduces to.
+	sort.Slice(bidiRuns, func(i, j int) bool { return bidiRuns[i].runeStart < bidiRuns[j].runeStart })
+	rtlParagraph := baseDirection == di.DirectionRTL
+	for i := 0; i < len(runes) && !rtlParagraph; i++ {
+		p, _ := bidi.LookupRune(runes[i])
+		c := p.Class()
+		if c == bidi.L {
+			break
+		}
+		rtlParagraph = c == bidi.R || c == bidi.AL
+	}
+	if rtlParagraph {
+		for l, r := 0, len(bidiRuns)-1; l < r; l, r = l+1, r-1 {
+			bidiRuns[l], bidiRuns[r] = bidiRuns[r], bidiRuns[l]
+		}
+	}
+	for i := 0; i < len(bidiRuns); {
+		if bidiRuns[i].dir != di.DirectionRTL {
+			i++
+			continue
+		}
+		j := i
+		for j < len(bidiRuns) && bidiRuns[j].dir == di.DirectionRTL {
+			j++
+		}
+		for l, r := i, j-1; l < r; l, r = l+1, r-1 {
+			bidiRuns[l], bidiRuns[r] = bidiRuns[r], bidiRuns[l]
+		}
+		i = j
+	}
+
+	var runs []textRun
+	for _, br := range bidiRuns {
+		scriptRuns := splitByScript(runes, br.runeStart, br.runeEnd, br.dir)
+		if br.dir == di.DirectionRTL {
+			// splitByScript walks left-to-right through the logical text, so
+			// for a right-to-left bidi run its sub-runs come out in logical
+			// (reverse-of-visual) order; flip them so the merged glyph list
+			// stays in true visual order.
+			for l, r := 0, len(scriptRuns)-1; l < r; l, r = l+1, r-1 {
+				scriptRuns[l], scriptRuns[r] = scriptRuns[r], scriptRuns[l]
+			}
+		}
+		runs = append(runs, scriptRuns...)
+	}
+	return runs
+}
+
+// splitByScript further divides runes[start:end] into runs of a single
+// Unicode script. Runes in Common or Inherited scripts (punctuation, digits,
+// combining marks, etc.) are merged into the surrounding run so that e.g.
+// "don't" is not split at the apostrophe.
+func splitByScript(runes []rune, start, end int, dir di.Direction) []textRun {
+	if start >= end {
+		return nil
+	}
+
+	var runs []textRun
+	runStart := start
+	runScript := runeScript(runes[start])
+	for i := start + 1; i < end; i++ {
+		s := runeScript(runes[i])
+		if s == scriptCommon || s == runScript {
+			continue
+		}
+		if runScript == scriptCommon {
+			runScript = s
+			continue
+		}
+		runs = append(runs, textRun{runeStart: runStart, runeEnd: i, direction: dir, script: runScript})
+		runStart = i
+		runScript = s
+	}
+	runs = append(runs, textRun{runeStart: runStart, runeEnd: end, direction: dir, script: runScript})
+	return runs
+}
+
+const scriptCommon = language.Script("Zyyy")
+
+// scriptTable maps stdlib unicode script range tables to go-text ISO 15924
+// script tags for the scripts that come up in practice; anything else is
+// reported as Common so it merges into whichever neighboring run it sits
+// next to.
+var scriptTable = []struct {
+	table  *unicode.RangeTable
+	script language.Script
+}{
+	{unicode.Latin, language.Script("Latn")},
+	{unicode.Arabic, language.Script("Arab")},
+	{unicode.Hebrew, language.Script("Hebr")},
+	{unicode.Han, language.Script("Hani")},
+	{unicode.Hiragana, language.Script("Hira")},
+	{unicode.Katakana, language.Script("Kana")},
+	{unicode.Hangul, language.Script("Hang")},
+	{unicode.Cyrillic, language.Script("Cyrl")},
+	{unicode.Greek, language.Script("Grek")},
+	{unicode.Thai, language.Script("Thai")},
+	{unicode.Devanagari, language.Script("Deva")},
+}
+
+func runeScript(r rune) language.Script {
+	if unicode.Is(unicode.Inherited, r) {
+		return scriptCommon
+	}
+	for _, e := range scriptTable {
+		if unicode.Is(e.table, r) {
+			return e.script
+		}
+	}
+	return scriptCommon
+}
+
+// fallbackChainKey returns a string identifying an ordered fallback chain, for
+// use as part of a cache key. GoTextFaceSource.id is unique per source for the
+// lifetime of the process, so concatenating ids is sufficient to distinguish
+// chains.
+func fallbackChainKey(fallbacks []*GoTextFaceSource) string {
+	if len(fallbacks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, f := range fallbacks {
+		fmt.Fprintf(&b, "%d,", f.id)
+	}
+	return b.String()
+}
+
+// SetFallbackSources sets an ordered list of GoTextFaceSource values to try
+// when g is missing a glyph (i.e. shaping produces the .notdef glyph, GID 0)
+// for some part of the text. This is useful for covering scripts or symbols
+// that a single font does not include, e.g. falling back from a Latin body
+// font to a CJK or emoji font.
+//
+// SetFallbackSources is not safe to call concurrently with text drawing using
+// g.
+func (g *GoTextFaceSource) SetFallbackSources(fallbacks []*GoTextFaceSource) {
+	g.m.Lock()
+	defer g.m.Unlock()
+	g.fallbackSources = append([]*GoTextFaceSource(nil), fallbacks...)
+}
+
 func (g *GoTextFaceSource) shape(text string, face *GoTextFace) (shaping.Output, []glyph) {
 	g.m.Lock()
 	defer g.m.Unlock()
 
 	key := face.outputCacheKey(text)
+	key.fallbacks = fallbackChainKey(g.fallbackSources)
 	if out, ok := g.outputCache[key]; ok {
 		out.atime = now()
 		return out.output, out.glyphs
 	}
 
-	g.f.SetVariations(face.variations)
 	runes := []rune(text)
-	input := shaping.Input{
-		Text:         runes,
-		RunStart:     0,
-		RunEnd:       len(runes),
-		Direction:    face.diDirection(),
-		Face:         face.Source.f,
-		FontFeatures: face.features,
-		Size:         float64ToFixed26_6(face.SizeInPixels),
-		Script:       face.gScript(),
-		Language:     language.Language(face.Language.String()),
-	}
-	out := (&shaping.HarfbuzzShaper{}).Shape(input)
-	if g.outputCache == nil {
-		g.outputCache = map[goTextOutputCacheKey]*goTextOutputCacheValue{}
-	}
 
 	var indices []int
 	for i := range text {
@@ -162,23 +366,102 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) (shaping.Output,
 	}
 	indices = append(indices, len(text))
 
+	runs := segmentText(runes, text, face.diDirection())
+
+	// Size is the same for every run: it comes straight from
+	// face.SizeInPixels, not from anything the shaper infers per-run.
+	mergedOut := shaping.Output{
+		Size: float64ToFixed26_6(face.SizeInPixels),
+	}
+	var gs []glyph
+	for _, run := range runs {
+		out, runGlyphs, _ := g.shapeRun(runes, indices, run, face)
+		mergedOut.Glyphs = append(mergedOut.Glyphs, out.Glyphs...)
+		mergedOut.LineBounds = unionBounds(mergedOut.LineBounds, out.LineBounds)
+		mergedOut.GlyphBounds = unionBounds(mergedOut.GlyphBounds, out.GlyphBounds)
+		mergedOut.Advance += out.Advance
+		gs = append(gs, runGlyphs...)
+	}
+
+	if g.outputCache == nil {
+		g.outputCache = map[goTextOutputCacheKey]*goTextOutputCacheValue{}
+	}
+	g.outputCache[key] = &goTextOutputCacheValue{
+		output: mergedOut,
+		glyphs: gs,
+		atime:  now(),
+	}
+
+	const cacheSoftLimit = 512
+	if len(g.outputCache) > cacheSoftLimit {
+		for key, e := range g.outputCache {
+			// 60 is an arbitrary number.
+			if e.atime >= now()-60 {
+				continue
+			}
+			delete(g.outputCache, key)
+		}
+	}
+
+	return mergedOut, gs
+}
+
+// shapeRun shapes a single bidi/script run with g, falling back to
+// g.fallbackSources in order whenever the result still contains a .notdef
+// glyph. It returns the shaping output, the resulting glyphs (with
+// startIndex/endIndex expressed as byte offsets into the original text), and
+// the GoTextFaceSource that was actually used to produce the glyphs.
+func (g *GoTextFaceSource) shapeRun(runes []rune, indices []int, run textRun, face *GoTextFace) (shaping.Output, []glyph, *GoTextFaceSource) {
+	sources := append([]*GoTextFaceSource{g}, g.fallbackSources...)
+
+	var out shaping.Output
+	var usedSource *GoTextFaceSource
+	for i, src := range sources {
+		src.f.SetVariations(face.variations)
+		input := shaping.Input{
+			Text:         runes,
+			RunStart:     run.runeStart,
+			RunEnd:       run.runeEnd,
+			Direction:    run.direction,
+			Face:         src.f,
+			FontFeatures: face.features,
+			Size:         float64ToFixed26_6(face.SizeInPixels),
+			Script:       run.script,
+			Language:     language.Language(face.Language.String()),
+		}
+		out = (&shaping.HarfbuzzShaper{}).Shape(input)
+		usedSource = src
+
+		if i == len(sources)-1 || !hasNotdef(out) {
+			break
+		}
+	}
+
 	gs := make([]glyph, len(out.Glyphs))
 	for i, gl := range out.Glyphs {
 		gl := gl
 		var segs []api.Segment
-		switch data := g.f.GlyphData(gl.GlyphID).(type) {
+		var bm *api.GlyphBitmap
+		switch data := usedSource.f.GlyphData(gl.GlyphID).(type) {
 		case api.GlyphOutline:
 			segs = data.Segments
 		case api.GlyphSVG:
+			// The SVG document's color-layer paints are outside what
+			// scaledSegments can represent (a single flat path list has no
+			// per-layer color), so only the glyph's fallback outline is
+			// used, drawn in the caller's fill color.
 			segs = data.Outline.Segments
 		case api.GlyphBitmap:
 			if data.Outline != nil {
 				segs = data.Outline.Segments
+			} else {
+				d := data
+				bm = &d
 			}
 		}
 
 		scaledSegs := make([]api.Segment, len(segs))
-		scale := float32(g.scale(fixed26_6ToFloat64(out.Size)))
+		scale := float32(usedSource.scale(fixed26_6ToFloat64(out.Size)))
 		for i, seg := range segs {
 			scaledSegs[i] = seg
 			for j := range seg.Args {
@@ -193,26 +476,40 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) (shaping.Output,
 			startIndex:     indices[gl.ClusterIndex],
 			endIndex:       indices[gl.ClusterIndex+gl.RuneCount],
 			scaledSegments: scaledSegs,
+			bitmap:         bm,
 		}
 	}
-	g.outputCache[key] = &goTextOutputCacheValue{
-		output: out,
-		glyphs: gs,
-		atime:  now(),
+
+	return out, gs, usedSource
+}
+
+// unionBounds combines two runs' Bounds into the smallest Bounds that
+// contains both, so that a multi-run line is measured by its tallest
+// ascent/descent/gap rather than whichever run happened to shape last.
+// Descent is negative below the baseline, so the deeper descent is the
+// smaller value.
+func unionBounds(a, b shaping.Bounds) shaping.Bounds {
+	if b.Ascent > a.Ascent {
+		a.Ascent = b.Ascent
+	}
+	if b.Descent < a.Descent {
+		a.Descent = b.Descent
 	}
+	if b.Gap > a.Gap {
+		a.Gap = b.Gap
+	}
+	return a
+}
 
-	const cacheSoftLimit = 512
-	if len(g.outputCache) > cacheSoftLimit {
-		for key, e := range g.outputCache {
-			// 60 is an arbitrary number.
-			if e.atime >= now()-60 {
-				continue
-			}
-			delete(g.outputCache, key)
+// hasNotdef reports whether out contains the .notdef glyph (GID 0), which
+// HarfBuzz emits when the face has no glyph for a rune.
+func hasNotdef(out shaping.Output) bool {
+	for _, gl := range out.Glyphs {
+		if gl.GlyphID == 0 {
+			return true
 		}
 	}
-
-	return out, gs
+	return false
 }
 
 func (g *GoTextFaceSource) scale(sizeInPixels float64) float64 {
@@ -0,0 +1,74 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/go-text/typesetting/di"
+)
+
+func runeRanges(runs []textRun) [][2]int {
+	out := make([][2]int, len(runs))
+	for i, r := range runs {
+		out[i] = [2]int{r.runeStart, r.runeEnd}
+	}
+	return out
+}
+
+func TestSegmentTextASCII(t *testing.T) {
+	text := "hello"
+	runs := segmentText([]rune(text), text, di.DirectionLTR)
+
+	got := runeRanges(runs)
+	want := [][2]int{{0, 5}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("segmentText(%q) runs = %v, want %v", text, got, want)
+	}
+}
+
+func TestSegmentTextCoversAllRunes(t *testing.T) {
+	// A mix of Latin and Arabic forces at least two bidi runs; regardless of
+	// how those runs get reordered for display, every rune in the input
+	// must appear in exactly one run, and runs must stay internally
+	// contiguous (runeStart < runeEnd, no gaps introduced by a bad index
+	// translation).
+	text := "abc ابج def"
+	runes := []rune(text)
+	runs := segmentText(runes, text, di.DirectionLTR)
+
+	var total int
+	seen := make([]bool, len(runes))
+	for _, r := range runs {
+		if r.runeStart < 0 || r.runeEnd > len(runes) || r.runeStart >= r.runeEnd {
+			t.Fatalf("run has invalid range [%d, %d) for %d runes", r.runeStart, r.runeEnd, len(runes))
+		}
+		for i := r.runeStart; i < r.runeEnd; i++ {
+			if seen[i] {
+				t.Fatalf("rune %d covered by more than one run", i)
+			}
+			seen[i] = true
+		}
+		total += r.runeEnd - r.runeStart
+	}
+	if total != len(runes) {
+		t.Fatalf("runs cover %d runes, want %d", total, len(runes))
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Fatalf("rune %d not covered by any run", i)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/png"
+
+	"github.com/go-text/typesetting/opentype/api"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/vector"
+)
+
+// VectorDrawOptions controls how DrawVector renders a string.
+type VectorDrawOptions struct {
+	// GeoM is the geometry matrix applied to the whole string, just like
+	// ebiten.DrawImageOptions.GeoM.
+	GeoM ebiten.GeoM
+
+	// Color is the fill color used for every glyph. If Color is nil,
+	// color.White is used.
+	Color color.Color
+
+	// AntiAlias enables edge anti-aliasing on the filled glyph paths.
+	AntiAlias bool
+}
+
+// DrawVector draws text on dst using face, rasterizing each glyph as a
+// filled vector.Path built from GoTextFaceSource's scaledSegments, instead of
+// going through the bitmap glyph-image atlas that the regular Draw uses.
+//
+// This makes glyphs sub-pixel accurate under rotation or non-integer
+// GeoM scaling, and avoids the atlas churn that comes from animating
+// face.SizeInPixels, at the cost of being more expensive to rasterize per
+// glyph than a cached bitmap.
+//
+// Glyphs with no outline, e.g. some bitmap-strike color emoji (api.GlyphBitmap
+// with a nil Outline), have no scaledSegments to build a path from, so they
+// fall back to decoding and drawing the strike's raw image data instead of
+// being skipped. A strike whose data image.Decode cannot decode (this file
+// registers only the PNG decoder) is not drawn.
+func DrawVector(dst *ebiten.Image, str string, face *GoTextFace, op *VectorDrawOptions) {
+	if op == nil {
+		op = &VectorDrawOptions{}
+	}
+	clr := op.Color
+	if clr == nil {
+		clr = color.White
+	}
+
+	_, glyphs := face.Source.shape(str, face)
+	pens := glyphPenPositions(glyphs)
+	for i, g := range glyphs {
+		switch {
+		case len(g.scaledSegments) != 0:
+			drawGlyphVector(dst, g, pens[i][0], pens[i][1], op.GeoM, clr, op.AntiAlias)
+		case g.bitmap != nil:
+			drawGlyphBitmap(dst, g, pens[i][0], pens[i][1], op.GeoM)
+		}
+	}
+}
+
+// glyphPenPositions returns, for each glyph in glyphs, the pen position (in
+// face-local space) it should be drawn at: the running sum of the preceding
+// glyphs' XAdvance/YAdvance, with the first glyph at the origin.
+func glyphPenPositions(glyphs []glyph) [][2]float64 {
+	pens := make([][2]float64, len(glyphs))
+	var penX, penY float64
+	for i, g := range glyphs {
+		pens[i] = [2]float64{penX, penY}
+		penX += fixed26_6ToFloat64(g.shapingGlyph.XAdvance)
+		penY += fixed26_6ToFloat64(g.shapingGlyph.YAdvance)
+	}
+	return pens
+}
+
+// drawGlyphVector builds a vector.Path out of g's already-scaled outline
+// segments and fills it. scaledSegments are in face-local glyph space (Y-down,
+// since shapeRun negates Y; origin at the glyph's own origin); the glyph's
+// shaped X/Y offset (YOffset is Y-up, so it is subtracted) and the
+// running pen position (the accumulated XAdvance/YAdvance of preceding
+// glyphs) are folded in as a translation before geoM is applied, the same
+// way the bitmap path positions its atlas entry.
+func drawGlyphVector(dst *ebiten.Image, g glyph, penX, penY float64, geoM ebiten.GeoM, clr color.Color, antiAlias bool) {
+	ox := float32(penX + fixed26_6ToFloat64(g.shapingGlyph.XOffset))
+	oy := float32(penY - fixed26_6ToFloat64(g.shapingGlyph.YOffset))
+
+	var path vector.Path
+	for _, seg := range g.scaledSegments {
+		switch seg.Op {
+		case api.SegmentOpMoveTo:
+			path.MoveTo(ox+seg.Args[0].X, oy+seg.Args[0].Y)
+		case api.SegmentOpLineTo:
+			path.LineTo(ox+seg.Args[0].X, oy+seg.Args[0].Y)
+		case api.SegmentOpQuadTo:
+			path.QuadTo(ox+seg.Args[0].X, oy+seg.Args[0].Y, ox+seg.Args[1].X, oy+seg.Args[1].Y)
+		case api.SegmentOpCubeTo:
+			path.CubicTo(ox+seg.Args[0].X, oy+seg.Args[0].Y, ox+seg.Args[1].X, oy+seg.Args[1].Y, ox+seg.Args[2].X, oy+seg.Args[2].Y)
+		}
+	}
+
+	vector.DrawFilledPath(dst, &path, clr, &vector.DrawPathOptions{
+		GeoM:      geoM,
+		AntiAlias: antiAlias,
+	})
+}
+
+// drawGlyphBitmap decodes g.bitmap's raw strike data and draws it at the
+// glyph's shaped offset and the running pen position, the same positioning
+// drawGlyphVector uses for outline glyphs.
+//
+// Unlike drawGlyphVector, this draws the strike's native pixels as-is: the
+// bitmap glyph table doesn't expose the strike's nominal ppem here, so there
+// is no way to rescale it to face.SizeInPixels the way outline glyphs are
+// scaled.
+func drawGlyphBitmap(dst *ebiten.Image, g glyph, penX, penY float64, geoM ebiten.GeoM) {
+	img, _, err := image.Decode(bytes.NewReader(g.bitmap.Data))
+	if err != nil {
+		return
+	}
+
+	ox := penX + fixed26_6ToFloat64(g.shapingGlyph.XOffset)
+	oy := penY - fixed26_6ToFloat64(g.shapingGlyph.YOffset)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(ox, oy)
+	op.GeoM.Concat(geoM)
+	dst.DrawImage(ebiten.NewImageFromImage(img), op)
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	"github.com/go-text/typesetting/shaping"
+)
+
+func TestGlyphPenPositionsAccumulates(t *testing.T) {
+	glyphs := []glyph{
+		{shapingGlyph: &shaping.Glyph{XAdvance: float64ToFixed26_6(10), YAdvance: 0}},
+		{shapingGlyph: &shaping.Glyph{XAdvance: float64ToFixed26_6(20), YAdvance: 0}},
+		{shapingGlyph: &shaping.Glyph{XAdvance: float64ToFixed26_6(5), YAdvance: 0}},
+	}
+
+	pens := glyphPenPositions(glyphs)
+	want := [][2]float64{{0, 0}, {10, 0}, {30, 0}}
+	for i := range want {
+		if pens[i] != want[i] {
+			t.Fatalf("pens[%d] = %v, want %v", i, pens[i], want[i])
+		}
+	}
+}
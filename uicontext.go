@@ -72,9 +72,28 @@ type uiContext struct {
 	outsideWidth       float64
 	outsideHeight      float64
 
+	lastDeviceScaleFactor float64
+
+	fpsMode                FPSMode
+	scheduleFrameRequested bool
+	lastCursorX            int
+	lastCursorY            int
+
 	m sync.Mutex
 }
 
+// LayoutFer is an optional interface a Game can implement in addition to
+// Layout. When a Game implements LayoutFer, LayoutF is used instead of
+// Layout to determine the logical screen size, taking outsideWidth and
+// outsideHeight (and thus the result) as float64 rather than int. This lets
+// a game lay out at the fractional device scale factors used by Windows
+// per-monitor-v2 DPI and Wayland fractional scaling, instead of having the
+// outside size truncated to a whole logical pixel. The returned width and
+// height are rounded up to whole pixels to size the offscreen image.
+type LayoutFer interface {
+	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
+}
+
 var theUIContext *uiContext
 
 func (c *uiContext) setScaleForWindow(scale float64) {
@@ -112,14 +131,57 @@ func (c *uiContext) SetScreenSize(width, height int) {
 	uiDriver().SetWindowSize(int(float64(width)*s), int(float64(height)*s))
 }
 
+func (c *uiContext) setFPSMode(mode FPSMode) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.fpsMode == mode {
+		return
+	}
+	c.fpsMode = mode
+	uiDriver().SetVsyncEnabled(mode == FPSModeVsyncOn)
+}
+
+func (c *uiContext) scheduleFrame() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.scheduleFrameRequested = true
+}
+
 func (c *uiContext) Layout(outsideWidth, outsideHeight float64) {
 	c.outsideSizeUpdated = true
 	c.outsideWidth = outsideWidth
 	c.outsideHeight = outsideHeight
 }
 
+func (c *uiContext) layout() (screenWidth, screenHeight int) {
+	if g, ok := c.game.(LayoutFer); ok {
+		w, h := g.LayoutF(c.outsideWidth, c.outsideHeight)
+		return int(math.Ceil(w)), int(math.Ceil(h))
+	}
+	return c.game.Layout(int(c.outsideWidth), int(c.outsideHeight))
+}
+
 func (c *uiContext) updateOffscreen() {
-	sw, sh := c.game.Layout(int(c.outsideWidth), int(c.outsideHeight))
+	sw, sh := c.layout()
+
+	// TODO: This is duplicated with mobile/ebitenmobileview/funcs.go. Refactor this.
+	d := uiDriver().DeviceScaleFactor()
+	if c.lastDeviceScaleFactor != 0 && c.lastDeviceScaleFactor != d {
+		// The window moved to a monitor with a different DPI, e.g. between a
+		// Retina and a non-Retina display. screen and offscreen were sized
+		// for the old factor, so they must be rebuilt at the new physical
+		// resolution even though the logical (outside) size hasn't changed,
+		// or text and vector art stay blurry until the user resizes the
+		// window by hand.
+		c.outsideSizeUpdated = true
+		if c.offscreen != nil {
+			_ = c.offscreen.Dispose()
+			c.offscreen = nil
+		}
+	}
+	c.lastDeviceScaleFactor = d
 
 	if c.offscreen != nil && !c.outsideSizeUpdated {
 		if w, h := c.offscreen.Size(); w == sw && h == sh {
@@ -144,8 +206,6 @@ func (c *uiContext) updateOffscreen() {
 	}
 	c.SetScreenSize(sw, sh)
 
-	// TODO: This is duplicated with mobile/ebitenmobileview/funcs.go. Refactor this.
-	d := uiDriver().DeviceScaleFactor()
 	c.screen = newScreenFramebufferImage(int(c.outsideWidth*d), int(c.outsideHeight*d))
 
 	scaleX := c.outsideWidth / float64(sw) * d
@@ -164,7 +224,29 @@ func (c *uiContext) updateOffscreen() {
 func (c *uiContext) Update(afterFrameUpdate func()) error {
 	updateCount := clock.Update(MaxTPS())
 
-	// TODO: If updateCount is 0 and vsync is disabled, swapping buffers can be skipped.
+	cx, cy := uiDriver().Input().CursorPosition()
+
+	c.m.Lock()
+	// inputChanged only tracks cursor movement, not key/mouse-button/wheel/
+	// touch/gamepad state, so those inputs don't wake a frame on their own
+	// under FPSModeVsyncOffMinimum; see the doc comment on that mode. Games
+	// that need to redraw on those inputs should call ScheduleFrame.
+	inputChanged := cx != c.lastCursorX || cy != c.lastCursorY
+	c.lastCursorX, c.lastCursorY = cx, cy
+	// Only FPSModeVsyncOffMinimum skips rendering when nothing changed:
+	// FPSModeVsyncOffMaximum must keep rendering as fast as possible even
+	// with no game update, and FPSModeVsyncOn relies on the driver's vsync
+	// to pace swaps.
+	skippable := updateCount == 0 && c.fpsMode == FPSModeVsyncOffMinimum && !c.scheduleFrameRequested && !inputChanged
+	c.scheduleFrameRequested = false
+	c.m.Unlock()
+
+	// When nothing has changed and FPSModeVsyncOffMinimum is in effect, skip
+	// the buffer swap entirely so idle windows cost ~0% CPU instead of
+	// redrawing an unchanged screen every tick.
+	if skippable {
+		return nil
+	}
 
 	if err := buffered.BeginFrame(); err != nil {
 		return err